@@ -0,0 +1,148 @@
+// Package filesystem provides an overlay implementation of tfconfig.FS that
+// serves unsaved editor buffers from memory, falling through to disk for
+// anything the editor hasn't opened. This lets config parsing see the same
+// content the user is looking at, rather than whatever was last saved.
+package filesystem
+
+import (
+	"bytes"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+)
+
+// Filesystem is a tfconfig.FS backed by a layered store: open editor
+// buffers are served from an in-memory overlay, keyed by URI, and
+// everything else falls through to the OS filesystem.
+type Filesystem struct {
+	osFs tfconfig.FS
+
+	docMu sync.RWMutex
+	docs  map[string][]byte
+}
+
+// NewFilesystem creates an empty overlay filesystem with no open documents.
+func NewFilesystem() *Filesystem {
+	return &Filesystem{
+		osFs: tfconfig.NewOsFs(),
+		docs: make(map[string][]byte),
+	}
+}
+
+// SetDocument stores or replaces the in-memory content for uri, used on
+// LSP didOpen/didChange notifications. uri is keyed by its filesystem path
+// so that later lookups by plain path (as used by Open/ReadFile) still hit
+// the overlay.
+func (fs *Filesystem) SetDocument(uri string, text []byte) {
+	fs.docMu.Lock()
+	defer fs.docMu.Unlock()
+	fs.docs[pathFromURI(uri)] = text
+}
+
+// RemoveDocument removes uri from the overlay, used on didClose/didSave so
+// that subsequent reads fall through to the saved content on disk.
+func (fs *Filesystem) RemoveDocument(uri string) {
+	fs.docMu.Lock()
+	defer fs.docMu.Unlock()
+	delete(fs.docs, pathFromURI(uri))
+}
+
+func (fs *Filesystem) document(name string) ([]byte, bool) {
+	fs.docMu.RLock()
+	defer fs.docMu.RUnlock()
+	text, ok := fs.docs[pathFromURI(name)]
+	return text, ok
+}
+
+// Open implements tfconfig.FS.
+func (fs *Filesystem) Open(name string) (tfconfig.File, error) {
+	if text, ok := fs.document(name); ok {
+		return newOverlayFile(name, text), nil
+	}
+	return fs.osFs.Open(name)
+}
+
+// ReadFile implements tfconfig.FS.
+func (fs *Filesystem) ReadFile(name string) ([]byte, error) {
+	if text, ok := fs.document(name); ok {
+		return text, nil
+	}
+	return fs.osFs.ReadFile(name)
+}
+
+// pathFromURI normalizes an LSP file:// URI to the plain filesystem path
+// tfconfig uses when calling Open/ReadFile. Values that are already plain
+// paths (no file:// scheme) are returned unchanged.
+func pathFromURI(uri string) string {
+	if !strings.HasPrefix(uri, "file://") {
+		return uri
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	path := u.Path
+	// On Windows, file:///C:/foo parses to a path of /C:/foo; strip the
+	// leading slash in front of the drive letter.
+	if len(path) >= 3 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+
+	return filepath.FromSlash(path)
+}
+
+// ReadDir implements tfconfig.FS. Open buffers are not reflected in
+// directory listings, as tfconfig only uses ReadDir to enumerate files
+// that already exist on disk.
+func (fs *Filesystem) ReadDir(dirname string) ([]os.FileInfo, error) {
+	return fs.osFs.ReadDir(dirname)
+}
+
+var _ tfconfig.FS = (*Filesystem)(nil)
+
+// overlayFile is a tfconfig.File backed by an in-memory byte slice.
+type overlayFile struct {
+	*bytes.Reader
+	name string
+	size int64
+}
+
+func newOverlayFile(name string, content []byte) *overlayFile {
+	return &overlayFile{
+		Reader: bytes.NewReader(content),
+		name:   name,
+		size:   int64(len(content)),
+	}
+}
+
+func (f *overlayFile) Stat() (os.FileInfo, error) {
+	return overlayFileInfo{name: f.name, size: f.size}, nil
+}
+
+func (f *overlayFile) Close() error {
+	return nil
+}
+
+var _ tfconfig.File = (*overlayFile)(nil)
+
+// overlayFileInfo is a minimal os.FileInfo for an in-memory document.
+type overlayFileInfo struct {
+	name string
+	size int64
+}
+
+func (fi overlayFileInfo) Name() string       { return fi.name }
+func (fi overlayFileInfo) Size() int64        { return fi.size }
+func (fi overlayFileInfo) Mode() os.FileMode  { return 0o644 }
+func (fi overlayFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi overlayFileInfo) IsDir() bool        { return false }
+func (fi overlayFileInfo) Sys() interface{}   { return nil }
+
+var _ os.FileInfo = overlayFileInfo{}
@@ -0,0 +1,40 @@
+// Package lockfile parses Terraform's plugin lock file, which records the
+// expected SHA256 hash of each provider plugin binary that was installed by
+// `terraform init`. It is used to detect plugins that have been swapped out
+// or corrupted since the last init.
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+)
+
+// Lockfile maps a provider name (e.g. "aws") to the hex-encoded SHA256 hash
+// of the plugin binary that Terraform installed for it.
+type Lockfile map[string]string
+
+// ParseFile reads and parses the plugin lock file at path.
+func ParseFile(path string) (Lockfile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Parse decodes the JSON plugin lock file format, a flat object of
+// provider name to hex-encoded SHA256 hash.
+func Parse(b []byte) (Lockfile, error) {
+	var lf Lockfile
+	if err := json.Unmarshal(b, &lf); err != nil {
+		return nil, fmt.Errorf("unable to parse plugin lock file: %w", err)
+	}
+	return lf, nil
+}
+
+// Hash returns the recorded hash for name and whether it was found.
+func (lf Lockfile) Hash(name string) (string, bool) {
+	hash, ok := lf[name]
+	return hash, ok
+}
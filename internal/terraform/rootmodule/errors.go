@@ -0,0 +1,22 @@
+package rootmodule
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/addrs"
+)
+
+// PluginHashMismatchError is returned when a discovered plugin binary's
+// SHA256 hash does not match the hash recorded for it in the plugin lock
+// file, indicating that the plugin on disk is stale or has been tampered
+// with since the last `terraform init`.
+type PluginHashMismatchError struct {
+	Provider addrs.Provider
+	Expected string
+	Actual   string
+}
+
+func (e *PluginHashMismatchError) Error() string {
+	return fmt.Sprintf("plugin hash mismatch for provider %s: expected %s, got %s "+
+		"(re-run terraform init to fix)", e.Provider, e.Expected, e.Actual)
+}
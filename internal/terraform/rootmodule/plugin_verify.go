@@ -0,0 +1,121 @@
+package rootmodule
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-ls/internal/terraform/addrs"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lockfile"
+)
+
+// verifyPluginHashes reads the plugin lock file and compares the recorded
+// hash of each provider against the SHA256 of the plugin binary actually
+// discovered on disk, to catch plugins that have gone stale since the
+// last `terraform init`.
+func (rm *rootModule) verifyPluginHashes() error {
+	rm.pluginVerifyErrorMu.Lock()
+	defer rm.pluginVerifyErrorMu.Unlock()
+
+	rm.pluginsVerified = false
+	rm.pluginVerifyErrors = nil
+
+	if rm.pluginLockFile == nil {
+		return nil
+	}
+
+	lf, err := lockfile.ParseFile(rm.pluginLockFile.Path())
+	if err != nil {
+		return fmt.Errorf("unable to parse plugin lock file: %w", err)
+	}
+
+	binaries, err := pluginBinaryPaths(filepath.Dir(rm.pluginLockFile.Path()))
+	if err != nil {
+		return fmt.Errorf("unable to discover plugin binaries: %w", err)
+	}
+
+	var errs *multierror.Error
+	for name, path := range binaries {
+		expected, ok := lf.Hash(name)
+		if !ok {
+			// no recorded hash for this plugin, nothing to verify
+			continue
+		}
+
+		actual, err := lockfile.SHA256(path)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		if actual != expected {
+			pAddr, pErr := addrs.ParseProviderSourceString(name)
+			if pErr != nil {
+				errs = multierror.Append(errs, pErr)
+				continue
+			}
+			errs = multierror.Append(errs, &PluginHashMismatchError{
+				Provider: pAddr,
+				Expected: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	if errs.ErrorOrNil() != nil {
+		rm.pluginVerifyErrors = errs.Errors
+		return errs.ErrorOrNil()
+	}
+
+	rm.pluginsVerified = true
+	return nil
+}
+
+// pluginBinaryPaths discovers terraform-provider-* plugin binaries in dir
+// and returns them keyed by provider name (e.g. "aws").
+func pluginBinaryPaths(dir string) (map[string]string, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	const prefix = "terraform-provider-"
+	binaries := make(map[string]string)
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+
+		name := strings.TrimPrefix(e.Name(), prefix)
+		if idx := strings.Index(name, "_"); idx > 0 {
+			name = name[:idx]
+		}
+
+		binaries[name] = filepath.Join(dir, e.Name())
+	}
+
+	return binaries, nil
+}
+
+// PluginsVerified reports whether the plugins discovered for this root
+// module matched the hashes recorded in the plugin lock file.
+func (rm *rootModule) PluginsVerified() bool {
+	rm.pluginVerifyErrorMu.RLock()
+	defer rm.pluginVerifyErrorMu.RUnlock()
+	return rm.pluginsVerified
+}
+
+// PluginVerificationErrors returns the errors found the last time plugin
+// hashes were verified, if any: hash mismatches as well as failures to
+// hash a plugin binary.
+func (rm *rootModule) PluginVerificationErrors() []error {
+	rm.pluginVerifyErrorMu.RLock()
+	defer rm.pluginVerifyErrorMu.RUnlock()
+	return rm.pluginVerifyErrors
+}
@@ -0,0 +1,17 @@
+package rootmodule
+
+import "github.com/hashicorp/terraform-ls/internal/terraform/addrs"
+
+// ProviderRequirement captures everything declared about a provider in a
+// required_providers block: its source address and any version constraints
+// the module places on it, regardless of whether the block used the older
+// string-constraint syntax or the newer object syntax with an explicit
+// source/version pair.
+type ProviderRequirement struct {
+	Addr               addrs.Provider
+	VersionConstraints []string
+}
+
+// ProviderRequirements maps each provider's local name, as used within the
+// module, to what it requires.
+type ProviderRequirements map[addrs.LocalProviderConfig]ProviderRequirement
@@ -0,0 +1,169 @@
+package rootmodule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/hashicorp/terraform-ls/internal/terraform/addrs"
+)
+
+// ResourceInstance describes a single managed or data resource known to a
+// root module, joining its declaration in config with its instance data
+// read from the current state.
+type ResourceInstance struct {
+	Type     string
+	Name     string
+	Mode     tfconfig.ResourceMode
+	Provider addrs.Provider
+	Range    hcl.Range
+	RemoteID string
+}
+
+// Addr returns the resource address in "type.name" form.
+func (r ResourceInstance) Addr() string {
+	return fmt.Sprintf("%s.%s", r.Type, r.Name)
+}
+
+// ResourceInstances is a queryable collection of ResourceInstance, as
+// returned by rootModule.Resources.
+type ResourceInstances []ResourceInstance
+
+// FilterByID returns the resources whose remote ID matches id.
+func (ris ResourceInstances) FilterByID(id string) ResourceInstances {
+	matches := make(ResourceInstances, 0)
+	for _, r := range ris {
+		if r.RemoteID == id {
+			matches = append(matches, r)
+		}
+	}
+	return matches
+}
+
+// FilterByType returns the resources of the given resource type.
+func (ris ResourceInstances) FilterByType(resType string) ResourceInstances {
+	matches := make(ResourceInstances, 0)
+	for _, r := range ris {
+		if r.Type == resType {
+			matches = append(matches, r)
+		}
+	}
+	return matches
+}
+
+// tfShowState is the subset of `terraform show -json` state output needed
+// to match state resources back up with their config declarations.
+type tfShowState struct {
+	Values *struct {
+		RootModule struct {
+			Resources []tfShowResource `json:"resources"`
+		} `json:"root_module"`
+	} `json:"values"`
+}
+
+type tfShowResource struct {
+	Type   string                 `json:"type"`
+	Name   string                 `json:"name"`
+	Values map[string]interface{} `json:"values"`
+}
+
+// Resources returns the resources known to this root module, combining the
+// parsed config with the current state. The result is cached and
+// invalidated whenever the state file changes, the same way schema and
+// module manifest caches are invalidated on their own lock files.
+func (rm *rootModule) Resources(ctx context.Context) (ResourceInstances, error) {
+	rm.resourcesMu.Lock()
+	defer rm.resourcesMu.Unlock()
+
+	if rm.resources != nil {
+		return rm.resources, nil
+	}
+
+	if !rm.IsTerraformLoaded() || rm.tfExec == nil {
+		return nil, fmt.Errorf("cannot read resources as terraform executor is not available yet")
+	}
+
+	raw, err := rm.tfExec.ShowStateJSON(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state: %w", err)
+	}
+
+	var state tfShowState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("unable to parse state: %w", err)
+	}
+
+	instances := make(ResourceInstances, 0)
+	if state.Values != nil {
+		for _, res := range state.Values.RootModule.Resources {
+			instances = append(instances, rm.toResourceInstance(res))
+		}
+	}
+
+	rm.resources = instances
+	return rm.resources, nil
+}
+
+func (rm *rootModule) toResourceInstance(res tfShowResource) ResourceInstance {
+	inst := ResourceInstance{
+		Type: res.Type,
+		Name: res.Name,
+		Mode: tfconfig.ManagedResourceMode,
+	}
+
+	if id, ok := res.Values["id"]; ok {
+		if s, ok := id.(string); ok {
+			inst.RemoteID = s
+		}
+	}
+
+	decl := rm.declaredResource(res.Type, res.Name)
+	if decl != nil {
+		inst.Mode = decl.Mode
+		inst.Range = hcl.Range{
+			Filename: decl.Pos.Filename,
+			Start:    hcl.Pos{Line: decl.Pos.Line},
+			End:      hcl.Pos{Line: decl.Pos.Line},
+		}
+
+		if pAddr, ok := rm.providerAddr(decl.Provider.Name); ok {
+			inst.Provider = pAddr
+		}
+	}
+
+	return inst
+}
+
+func (rm *rootModule) declaredResource(resType, name string) *tfconfig.Resource {
+	rm.providerRefsMu.RLock()
+	mod := rm.module
+	rm.providerRefsMu.RUnlock()
+
+	if mod == nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s.%s", resType, name)
+	if r, ok := mod.ManagedResources[key]; ok {
+		return r
+	}
+	if r, ok := mod.DataResources["data."+key]; ok {
+		return r
+	}
+
+	return nil
+}
+
+func (rm *rootModule) providerAddr(localName string) (addrs.Provider, bool) {
+	lc, err := addrs.ParseProviderConfigCompactStr(localName)
+	if err != nil {
+		return addrs.Provider{}, false
+	}
+
+	rm.providerRefsMu.RLock()
+	defer rm.providerRefsMu.RUnlock()
+	pAddr, ok := rm.providerRefs[lc]
+	return pAddr, ok
+}
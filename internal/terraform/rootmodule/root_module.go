@@ -17,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-ls/internal/terraform/discovery"
 	"github.com/hashicorp/terraform-ls/internal/terraform/exec"
 	"github.com/hashicorp/terraform-ls/internal/terraform/lang"
+	"github.com/hashicorp/terraform-ls/internal/terraform/lockfile"
 	"github.com/hashicorp/terraform-ls/internal/terraform/schema"
 )
 
@@ -38,12 +39,19 @@ type rootModule struct {
 	moduleManifest     *moduleManifest
 
 	// plugin cache
-	pluginMu         *sync.RWMutex
-	pluginLockFile   File
-	newSchemaStorage schema.StorageFactory
-	schemaStorage    *schema.Storage
-	schemaLoaded     bool
-	schemaLoadedMu   *sync.RWMutex
+	pluginMu             *sync.RWMutex
+	pluginLockFile       File
+	newSchemaStorage     schema.StorageFactory
+	schemaStorage        *schema.Storage
+	schemaLoaded         bool
+	schemaLoadedMu       *sync.RWMutex
+	schemaVersions       map[addrs.Provider]string
+	schemaLockFileHashes lockfile.Lockfile
+
+	// plugin integrity verification
+	pluginsVerified     bool
+	pluginVerifyErrors  []error
+	pluginVerifyErrorMu *sync.RWMutex
 
 	// terraform executor
 	tfLoaded      bool
@@ -67,22 +75,38 @@ type rootModule struct {
 
 	// provider references
 	providerRefs   addrs.ProviderReferences
+	providerReqs   ProviderRequirements
 	providerRefsMu *sync.RWMutex
+	module         *tfconfig.Module
+
+	// filesystem used for reading module config, overridable so LSP
+	// handlers can serve unsaved buffers ahead of disk content
+	filesystemMu *sync.RWMutex
+	filesystem   tfconfig.FS
+
+	// resource inventory
+	resourcesMu *sync.RWMutex
+	resources   ResourceInstances
+	stateFile   File
 }
 
 func newRootModule(dir string) *rootModule {
 	return &rootModule{
-		path:           dir,
-		logger:         defaultLogger,
-		providerRefs:   make(addrs.ProviderReferences, 0),
-		providerRefsMu: &sync.RWMutex{},
-		isLoadingMu:    &sync.RWMutex{},
-		loadErrMu:      &sync.RWMutex{},
-		moduleMu:       &sync.RWMutex{},
-		pluginMu:       &sync.RWMutex{},
-		schemaLoadedMu: &sync.RWMutex{},
-		tfLoadedMu:     &sync.RWMutex{},
-		parserMu:       &sync.RWMutex{},
+		path:                dir,
+		logger:              defaultLogger,
+		providerRefs:        make(addrs.ProviderReferences, 0),
+		providerRefsMu:      &sync.RWMutex{},
+		isLoadingMu:         &sync.RWMutex{},
+		loadErrMu:           &sync.RWMutex{},
+		moduleMu:            &sync.RWMutex{},
+		pluginMu:            &sync.RWMutex{},
+		schemaLoadedMu:      &sync.RWMutex{},
+		pluginVerifyErrorMu: &sync.RWMutex{},
+		tfLoadedMu:          &sync.RWMutex{},
+		parserMu:            &sync.RWMutex{},
+		filesystemMu:        &sync.RWMutex{},
+		filesystem:          tfconfig.NewOsFs(),
+		resourcesMu:         &sync.RWMutex{},
 	}
 }
 
@@ -117,9 +141,31 @@ func (rm *rootModule) discoverCaches(ctx context.Context, dir string) error {
 		errs = multierror.Append(errs, err)
 	}
 
+	err = rm.discoverStateFile(dir)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
 	return errs.ErrorOrNil()
 }
 
+func (rm *rootModule) discoverStateFile(dir string) error {
+	rm.resourcesMu.Lock()
+	defer rm.resourcesMu.Unlock()
+
+	lf, err := newFile(stateFilePath(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			rm.logger.Printf("no state file found: %s", err.Error())
+			return nil
+		}
+
+		return fmt.Errorf("unable to calculate hash: %w", err)
+	}
+	rm.stateFile = lf
+	return nil
+}
+
 func (rm *rootModule) discoverPluginCache(dir string) error {
 	rm.pluginMu.Lock()
 	defer rm.pluginMu.Unlock()
@@ -135,6 +181,14 @@ func (rm *rootModule) discoverPluginCache(dir string) error {
 		return fmt.Errorf("unable to calculate hash: %w", err)
 	}
 	rm.pluginLockFile = lf
+
+	// A hash mismatch is recorded via PluginsVerified/PluginVerificationErrors
+	// for the LSP to surface as a diagnostic, but it must not prevent the
+	// rest of root module discovery and loading from proceeding.
+	if err := rm.verifyPluginHashes(); err != nil {
+		rm.logger.Printf("plugin hash verification failed for %s: %s", rm.Path(), err.Error())
+	}
+
 	return nil
 }
 
@@ -302,6 +356,7 @@ func (rm *rootModule) findCompatibleStateStorage() error {
 	}
 	rm.schemaStorage = ss
 	rm.schemaStorage.SetLogger(rm.logger)
+	rm.schemaStorage.SetProviderRequirements(rm.getProviderRequirements())
 
 	if rm.IsParserLoaded() {
 		rm.parser.SetSchemaReader(rm.schemaStorage)
@@ -330,11 +385,37 @@ func (rm *rootModule) findCompatibleLangParser() error {
 	return nil
 }
 
+// SetFilesystem overrides the filesystem used to read module config,
+// allowing callers to share a single overlay filesystem across all root
+// modules so unsaved editor buffers are visible to parsing. Callers should
+// re-invoke ParseProviderReferences after a buffer changes to keep
+// providerRefs in sync with what's open in the editor.
+func (rm *rootModule) SetFilesystem(fs tfconfig.FS) {
+	rm.filesystemMu.Lock()
+	defer rm.filesystemMu.Unlock()
+	rm.filesystem = fs
+}
+
+func (rm *rootModule) getFilesystem() tfconfig.FS {
+	rm.filesystemMu.RLock()
+	defer rm.filesystemMu.RUnlock()
+	return rm.filesystem
+}
+
+// getProviderRequirements returns the requirements parsed from the most
+// recent ParseProviderReferences call, guarding against the concurrent
+// reload/reparse that StartLoading and buffer-driven reparsing can trigger.
+func (rm *rootModule) getProviderRequirements() ProviderRequirements {
+	rm.providerRefsMu.RLock()
+	defer rm.providerRefsMu.RUnlock()
+	return rm.providerReqs
+}
+
 func (rm *rootModule) ParseProviderReferences() error {
 	rm.providerRefsMu.Lock()
 	defer rm.providerRefsMu.Unlock()
 
-	mod, diags := tfconfig.LoadModuleFromFilesystem(rm.filesystem, rm.Path())
+	mod, diags := tfconfig.LoadModuleFromFilesystem(rm.getFilesystem(), rm.Path())
 	if diags.HasErrors() {
 		rm.logger.Printf("parsing provider references for %s failed: %s",
 			rm.Path(), diags.Error())
@@ -344,7 +425,10 @@ func (rm *rootModule) ParseProviderReferences() error {
 		return nil
 	}
 
+	rm.module = mod
+
 	refs := make(addrs.ProviderReferences, 0)
+	reqs := make(ProviderRequirements, 0)
 
 	rm.logger.Printf("%d provider references found for %s",
 		len(mod.RequiredProviders), rm.Path())
@@ -359,21 +443,41 @@ func (rm *rootModule) ParseProviderReferences() error {
 		if err != nil {
 			return err
 		}
+
+		var pAddr addrs.Provider
 		if rp.Source != "" {
-			pAddr, err := addrs.ParseProviderSourceString(rp.Source)
+			pAddr, err = addrs.ParseProviderSourceString(rp.Source)
 			if err != nil {
 				return err
 			}
 			refs[lName] = pAddr
 		}
+
+		// Record a requirement whenever either a source or a version
+		// constraint was declared, so the legacy string-only syntax
+		// (`required_providers = { aws = "~> 2.0" }`, which has no source)
+		// still has its version constraint captured, even though there's
+		// no provider address to resolve it against.
+		if rp.Source != "" || len(rp.VersionConstraints) > 0 {
+			reqs[lName] = ProviderRequirement{
+				Addr:               pAddr,
+				VersionConstraints: rp.VersionConstraints,
+			}
+		}
 	}
 
 	rm.providerRefs = refs
+	rm.providerReqs = reqs
 
 	if rm.IsParserLoaded() {
 		rm.parserMu.Lock()
 		defer rm.parserMu.Unlock()
 		rm.parser.SetProviderReferences(rm.providerRefs)
+		rm.parser.SetProviderRequirements(rm.providerReqs)
+	}
+
+	if rm.schemaStorage != nil {
+		rm.schemaStorage.SetProviderRequirements(rm.providerReqs)
 	}
 
 	return nil
@@ -529,13 +633,71 @@ func (rm *rootModule) UpdateSchemaCache(ctx context.Context, lockFile File) erro
 
 	rm.pluginLockFile = lockFile
 
-	return rm.schemaStorage.ObtainSchemasForModule(ctx,
-		rm.tfExec, rootModuleDirFromFilePath(lockFile.Path()))
+	var errs *multierror.Error
+	err := rm.verifyPluginHashes()
+	errs = multierror.Append(errs, err)
+
+	err = rm.obtainSchemasForChangedProviders(ctx, lockFile)
+	errs = multierror.Append(errs, err)
+
+	return errs.ErrorOrNil()
+}
+
+// obtainSchemasForChangedProviders diffs the plugin hashes last seen by this
+// method against the current lock file and only (re-)fetches schemas for
+// providers whose hash was added or changed, evicting any that were
+// removed, instead of refreshing every provider in the module on every
+// lock file change. On first call there is nothing to diff against, so
+// every provider in the lock file is treated as changed.
+func (rm *rootModule) obtainSchemasForChangedProviders(ctx context.Context, newLockFile File) error {
+	newLf, err := lockfile.ParseFile(newLockFile.Path())
+	if err != nil {
+		return fmt.Errorf("unable to parse plugin lock file: %w", err)
+	}
+
+	changed, removed := diffProviderHashes(rm.schemaLockFileHashes, newLf)
+
+	if rm.schemaVersions == nil {
+		rm.schemaVersions = make(map[addrs.Provider]string)
+	}
+
+	var errs *multierror.Error
+
+	for _, name := range changed {
+		pAddr, err := addrs.ParseProviderSourceString(name)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		if err := rm.schemaStorage.ObtainSchemaForProvider(ctx, rm.tfExec, pAddr); err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		rm.schemaVersions[pAddr] = newLf[name]
+	}
+
+	for _, name := range removed {
+		pAddr, err := addrs.ParseProviderSourceString(name)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+
+		rm.schemaStorage.RemoveSchemaForProvider(pAddr)
+		delete(rm.schemaVersions, pAddr)
+	}
+
+	rm.schemaLockFileHashes = newLf
+
+	return errs.ErrorOrNil()
 }
 
 func (rm *rootModule) PathsToWatch() []string {
 	rm.pluginMu.RLock()
 	rm.moduleMu.RLock()
+	rm.resourcesMu.RLock()
+	defer rm.resourcesMu.RUnlock()
 	defer rm.moduleMu.RUnlock()
 	defer rm.pluginMu.RUnlock()
 
@@ -546,6 +708,9 @@ func (rm *rootModule) PathsToWatch() []string {
 	if rm.moduleManifestFile != nil {
 		files = append(files, rm.moduleManifestFile.Path())
 	}
+	if rm.stateFile != nil {
+		files = append(files, rm.stateFile.Path())
+	}
 
 	return files
 }
@@ -571,3 +736,25 @@ func (rm *rootModule) IsKnownPluginLockFile(path string) bool {
 
 	return pathEquals(rm.pluginLockFile.Path(), path)
 }
+
+func (rm *rootModule) IsKnownStateFile(path string) bool {
+	rm.resourcesMu.RLock()
+	defer rm.resourcesMu.RUnlock()
+
+	if rm.stateFile == nil {
+		return false
+	}
+
+	return pathEquals(rm.stateFile.Path(), path)
+}
+
+// UpdateStateFile records that the state file has changed and invalidates
+// the cached resource inventory, so the next call to Resources re-reads it.
+func (rm *rootModule) UpdateStateFile(lockFile File) error {
+	rm.resourcesMu.Lock()
+	rm.stateFile = lockFile
+	rm.resources = nil
+	rm.resourcesMu.Unlock()
+
+	return nil
+}
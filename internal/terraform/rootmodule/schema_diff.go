@@ -0,0 +1,24 @@
+package rootmodule
+
+import "github.com/hashicorp/terraform-ls/internal/terraform/lockfile"
+
+// diffProviderHashes compares the plugin hashes last seen against the
+// current lock file contents and reports which provider names need a
+// fresh schema (added or changed) and which should be evicted (no longer
+// present). A nil/empty previous lockfile (as on first load) reports every
+// entry in current as changed.
+func diffProviderHashes(previous, current lockfile.Lockfile) (changed, removed []string) {
+	for name, hash := range current {
+		if oldHash, ok := previous[name]; !ok || oldHash != hash {
+			changed = append(changed, name)
+		}
+	}
+
+	for name := range previous {
+		if _, ok := current[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+
+	return changed, removed
+}
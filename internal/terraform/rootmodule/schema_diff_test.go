@@ -0,0 +1,67 @@
+package rootmodule
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-ls/internal/terraform/lockfile"
+)
+
+func TestDiffProviderHashes_FirstLoad(t *testing.T) {
+	// On first load there's no prior state to diff against, so every
+	// provider in the lock file must be treated as changed and fetched.
+	current := lockfile.Lockfile{
+		"aws":    "aaa",
+		"google": "bbb",
+	}
+
+	changed, removed := diffProviderHashes(nil, current)
+
+	sort.Strings(changed)
+	expected := []string{"aws", "google"}
+	if len(changed) != len(expected) {
+		t.Fatalf("expected %v changed, got %v", expected, changed)
+	}
+	for i := range expected {
+		if changed[i] != expected[i] {
+			t.Fatalf("expected %v changed, got %v", expected, changed)
+		}
+	}
+
+	if len(removed) != 0 {
+		t.Fatalf("expected no removed providers, got %v", removed)
+	}
+}
+
+func TestDiffProviderHashes_Unchanged(t *testing.T) {
+	previous := lockfile.Lockfile{"aws": "aaa"}
+	current := lockfile.Lockfile{"aws": "aaa"}
+
+	changed, removed := diffProviderHashes(previous, current)
+
+	if len(changed) != 0 {
+		t.Fatalf("expected no changed providers, got %v", changed)
+	}
+	if len(removed) != 0 {
+		t.Fatalf("expected no removed providers, got %v", removed)
+	}
+}
+
+func TestDiffProviderHashes_ChangedAndRemoved(t *testing.T) {
+	previous := lockfile.Lockfile{
+		"aws":    "aaa",
+		"google": "bbb",
+	}
+	current := lockfile.Lockfile{
+		"aws": "ccc",
+	}
+
+	changed, removed := diffProviderHashes(previous, current)
+
+	if len(changed) != 1 || changed[0] != "aws" {
+		t.Fatalf("expected [aws] changed, got %v", changed)
+	}
+	if len(removed) != 1 || removed[0] != "google" {
+		t.Fatalf("expected [google] removed, got %v", removed)
+	}
+}